@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+
+	cid "github.com/ipfs/go-cid"
+	ds "github.com/ipfs/go-datastore"
+	dsync "github.com/ipfs/go-datastore/sync"
+	libp2p "github.com/libp2p/go-libp2p"
+	discovery "github.com/libp2p/go-libp2p-discovery"
+	dht "github.com/libp2p/go-libp2p-kad-dht"
+	peer "github.com/libp2p/go-libp2p-peer"
+	pstore "github.com/libp2p/go-libp2p-peerstore"
+	pnet "github.com/libp2p/go-libp2p-pnet"
+	routing "github.com/libp2p/go-libp2p-routing"
+	rhost "github.com/libp2p/go-libp2p/p2p/host/routed"
+	ma "github.com/multiformats/go-multiaddr"
+	mh "github.com/multiformats/go-multihash"
+
+	"github.com/sriharikapu/sharding-p2p-poc/config"
+)
+
+// bootnodeRendezvous is the discovery rendezvous string a single
+// well-known bootnode advertises itself under, so other nodes can find it
+// without a hardcoded multiaddr.
+const bootnodeRendezvous = "shardp2p/v1"
+
+// bootnodeCID returns the deterministic content ID a bootnode Provides and
+// joining nodes FindProvidersAsync against, derived from bootnodeRendezvous.
+func bootnodeCID() (cid.Cid, error) {
+	sum, err := mh.Sum([]byte(bootnodeRendezvous), mh.SHA2_256, -1)
+	if err != nil {
+		return cid.Undef, err
+	}
+	return cid.NewCidV1(cid.Raw, sum), nil
+}
+
+// discoverBootnode looks up bootnodeRendezvous via r's DHT provider records
+// and rendezvous discovery, returning any bootnode peers found. It is used
+// by makeNode so a node started with only cfg.BootstrapPeers empty (or
+// stale) can still find a well-known bootnode advertised by runBootnode.
+func discoverBootnode(ctx context.Context, self peer.ID, r routing.IpfsRouting) []pstore.PeerInfo {
+	c, err := bootnodeCID()
+	if err != nil {
+		log.Printf("discoverBootnode: failed to compute rendezvous CID: %v", err)
+		return nil
+	}
+
+	var found []pstore.PeerInfo
+	for pi := range r.FindProvidersAsync(ctx, c, 1) {
+		if pi.ID == self {
+			continue
+		}
+		found = append(found, pi)
+	}
+	if len(found) > 0 {
+		return found
+	}
+
+	routingDiscovery := discovery.NewRoutingDiscovery(r)
+	rendezvousPeers, err := routingDiscovery.FindPeers(ctx, bootnodeRendezvous)
+	if err != nil {
+		log.Printf("discoverBootnode: rendezvous lookup failed: %v", err)
+		return found
+	}
+	for pi := range rendezvousPeers {
+		if pi.ID == self {
+			continue
+		}
+		found = append(found, pi)
+	}
+	return found
+}
+
+// runBootnode starts a stripped-down node that only joins the DHT and
+// advertises bootnodeRendezvous, analogous to go-ethereum's cmd/bootnode.
+// It returns only if setup fails; otherwise it blocks forever, so callers
+// should run it in its own process.
+func runBootnode(ctx context.Context, cfg *config.Config, psk pnet.PSK) error {
+	priv, pid, err := loadOrCreateIdentity(cfg.DataDir)
+	if err != nil {
+		return err
+	}
+
+	opts := []libp2p.Option{
+		libp2p.Identity(priv),
+		libp2p.ListenAddrStrings(cfg.ListenAddrs...),
+	}
+	if psk != nil {
+		opts = append(opts, libp2p.PrivateNetwork(psk))
+	}
+
+	basicHost, err := libp2p.New(ctx, opts...)
+	if err != nil {
+		return err
+	}
+
+	dstore := dsync.MutexWrap(ds.NewMapDatastore())
+	kadDHT := dht.NewDHT(ctx, basicHost, dstore)
+	routedHost := rhost.Wrap(basicHost, kadDHT)
+	if err := kadDHT.Bootstrap(ctx); err != nil {
+		return err
+	}
+
+	rendezvousCid, err := bootnodeCID()
+	if err != nil {
+		return err
+	}
+	log.Printf("bootnode %s: advertising rendezvous %q (%s)", pid.Pretty(), bootnodeRendezvous, rendezvousCid)
+	if err := kadDHT.Provide(ctx, rendezvousCid, true); err != nil {
+		return err
+	}
+	routingDiscovery := discovery.NewRoutingDiscovery(kadDHT)
+	if _, err := routingDiscovery.Advertise(ctx, bootnodeRendezvous); err != nil {
+		return err
+	}
+
+	hostAddr, err := ma.NewMultiaddr(fmt.Sprintf("/ipfs/%s", pid.Pretty()))
+	if err != nil {
+		return err
+	}
+	addr := routedHost.Addrs()[0].Encapsulate(hostAddr).String()
+	log.Printf("bootnode listening at %s", addr)
+	if err := ioutil.WriteFile(filepath.Join(cfg.DataDir, "bootnode.addr"), []byte(addr+"\n"), 0644); err != nil {
+		log.Printf("bootnode: failed to persist address: %v", err)
+	}
+
+	select {}
+}
@@ -0,0 +1,20 @@
+// Command sharding-p2p-genpsk writes a new libp2p private-network key, for
+// use with the node's -psk flag.
+package main
+
+import (
+	"flag"
+	"log"
+
+	"github.com/sriharikapu/sharding-p2p-poc/netkey"
+)
+
+func main() {
+	out := flag.String("out", "swarm.key", "path to write the new key to")
+	flag.Parse()
+
+	if err := netkey.WriteNew(*out); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("wrote new private network key to %s", *out)
+}
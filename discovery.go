@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	cid "github.com/ipfs/go-cid"
+	discovery "github.com/libp2p/go-libp2p-discovery"
+	peer "github.com/libp2p/go-libp2p-peer"
+	pstore "github.com/libp2p/go-libp2p-peerstore"
+	mh "github.com/multiformats/go-multihash"
+)
+
+const (
+	// shardProvideInterval is how often a node re-announces itself as a
+	// provider for each shard it listens to; DHT provider records expire
+	// and need periodic renewal.
+	shardProvideInterval = 1 * time.Minute
+
+	// shardDiscoveryTargetPeers is how many shard-serving peers a node
+	// tries to stay directly connected to per shard.
+	shardDiscoveryTargetPeers = 6
+)
+
+// shardCID returns the deterministic content ID peers Provide() and
+// FindProvidersAsync() against to discover who is serving shardID.
+func shardCID(shardID ShardIDType) (cid.Cid, error) {
+	sum, err := mh.Sum([]byte(fmt.Sprintf("/shard/%d", shardID)), mh.SHA2_256, -1)
+	if err != nil {
+		return cid.Undef, err
+	}
+	return cid.NewCidV1(cid.Raw, sum), nil
+}
+
+// shardRendezvous returns the libp2p-discovery rendezvous string used for
+// faster bootstrap alongside the DHT provider record above.
+func shardRendezvous(shardID ShardIDType) string {
+	return fmt.Sprintf("shardp2p/shard/%d", shardID)
+}
+
+// startShardDiscovery provides shardID's content ID, advertises its
+// rendezvous string, and connects to discovered peers until ctx is
+// cancelled (by UnlistenShard) or shardDiscoveryTargetPeers is reached.
+// It records the time-to-first-peer in the shardDiscoveryLatency metric.
+func (n *Node) startShardDiscovery(ctx context.Context, shardID ShardIDType) {
+	c, err := shardCID(shardID)
+	if err != nil {
+		log.Printf("%s: shard %d: failed to compute discovery CID: %v", n.Name(), shardID, err)
+		return
+	}
+	routingDiscovery := discovery.NewRoutingDiscovery(n.Routing)
+
+	start := time.Now()
+	var latencyRecorded bool
+
+	provide := func() {
+		if err := n.Routing.Provide(ctx, c, true); err != nil {
+			log.Printf("%s: shard %d: provide failed: %v", n.Name(), shardID, err)
+		}
+		if _, err := routingDiscovery.Advertise(ctx, shardRendezvous(shardID)); err != nil {
+			log.Printf("%s: shard %d: advertise failed: %v", n.Name(), shardID, err)
+		}
+	}
+	provide()
+
+	go func() {
+		ticker := time.NewTicker(shardProvideInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				provide()
+			}
+		}
+	}()
+
+	connectPeer := func(pi pstore.PeerInfo) {
+		if pi.ID == n.ID() || n.numShardPeers(shardID) >= shardDiscoveryTargetPeers {
+			return
+		}
+		if err := n.Connect(ctx, pi); err != nil {
+			return
+		}
+		n.addShardPeer(shardID, pi.ID)
+		if !latencyRecorded {
+			latencyRecorded = true
+			recordDiscoveryLatency(shardID, time.Since(start))
+		}
+	}
+
+	for pi := range n.Routing.FindProvidersAsync(ctx, c, shardDiscoveryTargetPeers) {
+		connectPeer(pi)
+	}
+
+	rendezvousPeers, err := routingDiscovery.FindPeers(ctx, shardRendezvous(shardID))
+	if err == nil {
+		for pi := range rendezvousPeers {
+			connectPeer(pi)
+		}
+	}
+}
+
+// numShardPeers and addShardPeer track the set of peers connected to
+// because of shard discovery, backing Node.ShardPeers. Guarded by
+// n.shardPeersMu, a per-Node lock, since a single process can host more
+// than one Node (e.g. the sim package).
+func (n *Node) numShardPeers(shardID ShardIDType) int {
+	n.shardPeersMu.Lock()
+	defer n.shardPeersMu.Unlock()
+	return len(n.shardPeerSet(shardID))
+}
+
+func (n *Node) addShardPeer(shardID ShardIDType, p peer.ID) {
+	n.shardPeersMu.Lock()
+	defer n.shardPeersMu.Unlock()
+	if n.shardDiscoveredPeers == nil {
+		n.shardDiscoveredPeers = make(map[ShardIDType]map[peer.ID]struct{})
+	}
+	set := n.shardPeerSet(shardID)
+	set[p] = struct{}{}
+	n.shardDiscoveredPeers[shardID] = set
+}
+
+func (n *Node) shardPeerSet(shardID ShardIDType) map[peer.ID]struct{} {
+	if n.shardDiscoveredPeers == nil {
+		return map[peer.ID]struct{}{}
+	}
+	set, ok := n.shardDiscoveredPeers[shardID]
+	if !ok {
+		return map[peer.ID]struct{}{}
+	}
+	return set
+}
+
+// ShardPeers returns the peers this node discovered and connected to for
+// shardID via DHT provider records and rendezvous discovery.
+func (n *Node) ShardPeers(shardID ShardIDType) []peer.ID {
+	n.shardPeersMu.Lock()
+	defer n.shardPeersMu.Unlock()
+	set := n.shardPeerSet(shardID)
+	peers := make([]peer.ID, 0, len(set))
+	for p := range set {
+		peers = append(peers, p)
+	}
+	return peers
+}
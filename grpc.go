@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net"
+
+	peer "github.com/libp2p/go-libp2p-peer"
+	grpcp2p "github.com/paralin/go-libp2p-grpc"
+	grpc "google.golang.org/grpc"
+
+	pb "github.com/sriharikapu/sharding-p2p-poc/proto"
+)
+
+// nodeGRPCProtocol is the libp2p protocol ID the gRPC service is mounted
+// on, so node-to-node admin calls can ride an existing stream instead of
+// requiring an extra listening port.
+const nodeGRPCProtocol = "/shardp2p/grpc/1.0.0"
+
+// nodeServer adapts a Node to the generated NodeServiceServer interface.
+type nodeServer struct {
+	node *Node
+}
+
+func (s *nodeServer) AddPeer(ctx context.Context, req *pb.AddPeerRequest) (*pb.AddPeerReply, error) {
+	if err := s.node.AddPeer(req.Multiaddr); err != nil {
+		return nil, err
+	}
+	return &pb.AddPeerReply{Success: true}, nil
+}
+
+func (s *nodeServer) ListShards(ctx context.Context, req *pb.ListShardsRequest) (*pb.ListShardsReply, error) {
+	return &pb.ListShardsReply{ShardIds: s.node.ListeningShards()}, nil
+}
+
+func (s *nodeServer) SubscribeShard(ctx context.Context, req *pb.SubscribeShardRequest) (*pb.SubscribeShardReply, error) {
+	s.node.ListenShard(req.ShardId)
+	return &pb.SubscribeShardReply{Success: true}, nil
+}
+
+func (s *nodeServer) UnsubscribeShard(ctx context.Context, req *pb.UnsubscribeShardRequest) (*pb.UnsubscribeShardReply, error) {
+	s.node.UnlistenShard(req.ShardId)
+	return &pb.UnsubscribeShardReply{Success: true}, nil
+}
+
+func (s *nodeServer) SendCollation(ctx context.Context, req *pb.SendCollationRequest) (*pb.SendCollationReply, error) {
+	s.node.SendCollation(req.ShardId, req.Period, string(req.Blob))
+	return &pb.SendCollationReply{Success: true}, nil
+}
+
+func (s *nodeServer) ListPeers(ctx context.Context, req *pb.ListPeersRequest) (*pb.ListPeersReply, error) {
+	peers := s.node.Peerstore().Peers()
+	peerIDs := make([]string, 0, len(peers))
+	for _, p := range peers {
+		if p == s.node.ID() {
+			continue
+		}
+		peerIDs = append(peerIDs, p.Pretty())
+	}
+	return &pb.ListPeersReply{PeerIds: peerIDs}, nil
+}
+
+func (s *nodeServer) FindPeer(ctx context.Context, req *pb.FindPeerRequest) (*pb.FindPeerReply, error) {
+	pid, err := peer.IDB58Decode(req.PeerId)
+	if err != nil {
+		return nil, err
+	}
+	pi, err := s.node.FindPeer(ctx, pid)
+	if err != nil {
+		return nil, err
+	}
+	addrs := make([]string, 0, len(pi.Addrs))
+	for _, addr := range pi.Addrs {
+		addrs = append(addrs, addr.String())
+	}
+	return &pb.FindPeerReply{Multiaddrs: addrs}, nil
+}
+
+func (s *nodeServer) NodeInfo(ctx context.Context, req *pb.NodeInfoRequest) (*pb.NodeInfoReply, error) {
+	addrs := make([]string, 0, len(s.node.Addrs()))
+	for _, addr := range s.node.Addrs() {
+		addrs = append(addrs, addr.String())
+	}
+	return &pb.NodeInfoReply{PeerId: s.node.ID().Pretty(), Addrs: addrs}, nil
+}
+
+// serveGRPC registers node's NodeService on a local TCP listener (for
+// sharding-p2p-cli) and directly on node's libp2p host (for node-to-node
+// admin calls), and serves both until the process exits.
+func serveGRPC(node *Node, localAddr string) error {
+	srv := &nodeServer{node: node}
+
+	tcpListener, err := net.Listen("tcp", localAddr)
+	if err != nil {
+		return err
+	}
+	tcpServer := grpc.NewServer()
+	pb.RegisterNodeServiceServer(tcpServer, srv)
+	go func() {
+		log.Printf("%s: gRPC listening on %s", node.Name(), localAddr)
+		if err := tcpServer.Serve(tcpListener); err != nil {
+			log.Printf("%s: gRPC TCP server stopped: %v", node.Name(), err)
+		}
+	}()
+
+	p2pListener := grpcp2p.NewP2PGrpcListener(context.Background(), node.RoutedHost, nodeGRPCProtocol)
+	p2pServer := grpc.NewServer()
+	pb.RegisterNodeServiceServer(p2pServer, srv)
+	log.Printf("%s: gRPC mounted on libp2p protocol %s", node.Name(), nodeGRPCProtocol)
+	return p2pServer.Serve(p2pListener)
+}
+
+// dialNodeClient dials a node's local gRPC listener at addr, for use by
+// sharding-p2p-cli.
+func dialNodeClient(addr string) (pb.NodeServiceClient, *grpc.ClientConn, error) {
+	conn, err := grpc.Dial(addr, grpc.WithInsecure())
+	if err != nil {
+		return nil, nil, err
+	}
+	return pb.NewNodeServiceClient(conn), conn, nil
+}
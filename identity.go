@@ -0,0 +1,57 @@
+package main
+
+import (
+	"crypto/rand"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	crypto "github.com/libp2p/go-libp2p-crypto"
+	peer "github.com/libp2p/go-libp2p-peer"
+)
+
+const identityFileName = "identity.key"
+const identityFileMode = 0600
+
+// loadOrCreateIdentity loads the node's Ed25519 private key from
+// <dataDir>/identity.key, generating and persisting a new one on first run.
+// This replaces deriving the key from a numeric -seed, which left every
+// deployment sharing a seed with the same private key.
+func loadOrCreateIdentity(dataDir string) (crypto.PrivKey, peer.ID, error) {
+	if err := os.MkdirAll(dataDir, 0700); err != nil {
+		return nil, "", err
+	}
+	keyPath := filepath.Join(dataDir, identityFileName)
+
+	if data, err := ioutil.ReadFile(keyPath); err == nil {
+		priv, err := crypto.UnmarshalPrivateKey(data)
+		if err != nil {
+			return nil, "", err
+		}
+		pid, err := peer.IDFromPrivateKey(priv)
+		if err != nil {
+			return nil, "", err
+		}
+		return priv, pid, nil
+	} else if !os.IsNotExist(err) {
+		return nil, "", err
+	}
+
+	priv, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		return nil, "", err
+	}
+	data, err := crypto.MarshalPrivateKey(priv)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := ioutil.WriteFile(keyPath, data, identityFileMode); err != nil {
+		return nil, "", err
+	}
+
+	pid, err := peer.IDFromPrivateKey(priv)
+	if err != nil {
+		return nil, "", err
+	}
+	return priv, pid, nil
+}
@@ -3,7 +3,6 @@ package main
 import (
 	"context"
 	"flag"
-	"fmt"
 	"log"
 	"math"
 	mrand "math/rand"
@@ -20,9 +19,14 @@ import (
 	dht "github.com/libp2p/go-libp2p-kad-dht"
 	peer "github.com/libp2p/go-libp2p-peer"
 	pstore "github.com/libp2p/go-libp2p-peerstore"
+	pnet "github.com/libp2p/go-libp2p-pnet"
 	rhost "github.com/libp2p/go-libp2p/p2p/host/routed"
 	ma "github.com/multiformats/go-multiaddr"
 	gologging "github.com/whyrusleeping/go-logging"
+
+	"github.com/sriharikapu/sharding-p2p-poc/config"
+	"github.com/sriharikapu/sharding-p2p-poc/netkey"
+	pb "github.com/sriharikapu/sharding-p2p-poc/proto"
 )
 
 // import "C"
@@ -31,21 +35,14 @@ type ShardIDType = int64
 
 const numShards ShardIDType = 100
 
+// makeKey is kept around purely so -find can still resolve a peer ID from a
+// legacy numeric seed; it is no longer used to derive a node's own identity.
 func makeKey(seed int64) (ic.PrivKey, peer.ID, error) {
-	// If the seed is zero, use real cryptographic randomness. Otherwise, use a
-	// deterministic randomness source to make generated keys stay the same
-	// across multiple runs
 	r := mrand.New(mrand.NewSource(seed))
-	// r := rand.Reader
-
-	// Generate a key pair for this host. We will use it at least
-	// to obtain a valid host ID.
 	priv, _, err := crypto.GenerateKeyPairWithReader(crypto.RSA, 2048, r)
 	if err != nil {
 		return nil, "", err
 	}
-
-	// Get the peer id
 	pid, err := peer.IDFromPrivateKey(priv)
 	if err != nil {
 		return nil, "", err
@@ -53,26 +50,30 @@ func makeKey(seed int64) (ic.PrivKey, peer.ID, error) {
 	return priv, pid, nil
 }
 
-// makeNode creates a LibP2P host with a random peer ID listening on the
-// given multiaddress. It will use secio if secio is true.
-func makeNode(
-	ctx context.Context,
-	listenPort int,
-	randseed int64,
-	bootstrapPeers []pstore.PeerInfo) (*Node, error) {
-
-	listenAddrString := fmt.Sprintf("/ip4/0.0.0.0/tcp/%d", listenPort)
+// makeNode creates a LibP2P host using the identity persisted under
+// cfg.DataDir, listening on cfg.ListenAddrs and dialing cfg.BootstrapPeers.
+// If psk is non-nil, the host only talks to peers sharing the same
+// private-network key.
+func makeNode(ctx context.Context, cfg *config.Config, psk pnet.PSK) (*Node, error) {
+	priv, pid, err := loadOrCreateIdentity(cfg.DataDir)
+	if err != nil {
+		return nil, err
+	}
 
-	priv, _, err := makeKey(randseed)
+	bootstrapPeers, err := parseBootstrapPeers(cfg.BootstrapPeers)
 	if err != nil {
 		return nil, err
 	}
 
-	basicHost, err := libp2p.New(
-		ctx,
+	opts := []libp2p.Option{
 		libp2p.Identity(priv),
-		libp2p.ListenAddrStrings(listenAddrString),
-	)
+		libp2p.ListenAddrStrings(cfg.ListenAddrs...),
+	}
+	if psk != nil {
+		opts = append(opts, libp2p.PrivateNetwork(psk))
+	}
+
+	basicHost, err := libp2p.New(ctx, opts...)
 	if err != nil {
 		panic(err)
 	}
@@ -95,33 +96,44 @@ func makeNode(
 		return nil, err
 	}
 
-	// Make a host that listens on the given multiaddress
-	node := NewNode(ctx, routedHost, int(randseed))
+	// Fall back to discovering a well-known bootnode via its rendezvous
+	// advertisement when no bootstrap peers were configured (or none of
+	// them were reachable), so a node can still join the swarm.
+	if len(routedHost.Network().Peers()) == 0 {
+		bootnodePeers := discoverBootnode(ctx, pid, dht)
+		if len(bootnodePeers) > 0 {
+			bootstrapConnect(ctx, routedHost, bootnodePeers)
+		}
+	}
+
+	ps, err := newPubSub(ctx, routedHost)
+	if err != nil {
+		return nil, err
+	}
+
+	node := NewNode(ctx, routedHost, dht, ps, priv, pid.Pretty())
 
 	log.Printf("I am %s\n", node.GetFullAddr())
 
 	return node, nil
 }
 
-const (
-	portBase    = 10000
-	rpcPortBase = 13000
-)
-
-func addPeer(n *Node, seed int64, ip string, port int64) bool {
-	_, targetPID, err := makeKey(seed)
-	mAddr := fmt.Sprintf(
-		"/ip4/%s/tcp/%d/ipfs/%s",
-		ip,
-		port,
-		targetPID.Pretty(),
-	)
-	if err != nil {
-		log.Fatal(err)
+// parseBootstrapPeers turns the multiaddr strings from a Config into
+// pstore.PeerInfo values suitable for bootstrapConnect.
+func parseBootstrapPeers(addrs []string) ([]pstore.PeerInfo, error) {
+	peerInfos := make([]pstore.PeerInfo, 0, len(addrs))
+	for _, addr := range addrs {
+		maddr, err := ma.NewMultiaddr(addr)
+		if err != nil {
+			return nil, err
+		}
+		pi, err := pstore.InfoFromP2pAddr(maddr)
+		if err != nil {
+			return nil, err
+		}
+		peerInfos = append(peerInfos, *pi)
 	}
-	n.AddPeer(mAddr)
-	time.Sleep(time.Second * 1)
-	return true
+	return peerInfos, nil
 }
 
 func main() {
@@ -132,26 +144,57 @@ func main() {
 
 	// Parse options from the command line
 
-	targetSeed := flag.Int64("target-seed", -1, "target peer's seed")
-	targetIP := flag.String("target-ip", "", "target peer's ip")
-	seed := flag.Int64("seed", 0, "set random seed for id generation")
+	configPath := flag.String("config", "", "path to a node config file")
 	listenShards := flag.Int64("listen-shards", 0, "number of shards listened")
 	sendCollationOption := flag.String("send", "", "send collations")
 	peerSeed := flag.Int64("find", -1, "use dht to find a certain peer with the given peerSeed")
 	isClient := flag.Bool("client", false, "is RPC client or server")
+	pskPath := flag.String("psk", "", "path to a private-network key; overrides the config file")
+	isBootnode := flag.Bool("bootnode", false, "run as a stripped-down DHT bootnode instead of a full node")
+	bootstrapAddrs := flag.String("bootstrap", "", "comma-separated bootstrap multiaddrs; appended to the config file's bootstrapPeers")
 	flag.Parse()
-	// log.Print(*isClient)
-	// log.Print(flag.Args())
-	// log.Print(*seed)
-	// log.Print(reflection.TypeOf())
-	// return
 
-	listenPort := portBase + int32(*seed)
-	rpcPort := rpcPortBase + int32(*seed)
-	rpcAddr := fmt.Sprintf("127.0.0.1:%v", rpcPort)
+	var cfg *config.Config
+	if *configPath != "" {
+		loaded, err := config.Load(*configPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		cfg = loaded
+	} else {
+		cfg = config.Default()
+	}
+	if *pskPath != "" {
+		cfg.PSKPath = *pskPath
+	}
+	if *bootstrapAddrs != "" {
+		cfg.BootstrapPeers = append(cfg.BootstrapPeers, strings.Split(*bootstrapAddrs, ",")...)
+	}
+	level, err := gologging.LogLevel(cfg.LogLevel)
+	if err != nil {
+		log.Fatalf("invalid log level %q: %v", cfg.LogLevel, err)
+	}
+	golog.SetAllLoggers(level)
+
+	var psk pnet.PSK
+	if cfg.PSKPath != "" {
+		loaded, err := netkey.Load(cfg.PSKPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		psk = loaded
+	}
 
 	ctx := context.Background()
-	node, err := makeNode(ctx, int(listenPort), *seed, []pstore.PeerInfo{})
+
+	if *isBootnode {
+		if err := runBootnode(ctx, cfg, psk); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	node, err := makeNode(ctx, cfg, psk)
 
 	if err != nil {
 		log.Fatal(err)
@@ -165,16 +208,17 @@ func main() {
 		rpcCmd := flag.Args()[0]
 		rpcArgs := flag.Args()[1:]
 		if rpcCmd == "addpeer" {
-			if len(rpcArgs) != 2 {
-				log.Fatalf("Client mode: addpeer: wrong args")
+			if len(rpcArgs) != 1 {
+				log.Fatalf("Client mode: addpeer: wrong args, want a single target multiaddr")
 			}
-			targetIP := rpcArgs[0]
-			targetSeed, err := strconv.ParseInt(rpcArgs[1], 10, 64)
+			client, conn, err := dialNodeClient(cfg.RPCAddr)
 			if err != nil {
-				panic(err)
+				log.Fatal(err)
+			}
+			defer conn.Close()
+			if _, err := client.AddPeer(context.Background(), &pb.AddPeerRequest{Multiaddr: rpcArgs[0]}); err != nil {
+				log.Fatal(err)
 			}
-			targetPort := portBase + targetSeed
-			callRPCAddPeer(rpcAddr, targetIP, int(targetPort), targetSeed)
 			return
 		}
 	}
@@ -198,8 +242,17 @@ func main() {
 	}
 
 	log.Printf("Sending subscriptions...")
-	for i := ShardIDType(0); i < *listenShards; i++ {
-		node.ListenShard(i)
+	// cfg.ListenShards, when set, is authoritative over -listen-shards; the
+	// flag remains as a quick way to listen on shards 0..N without writing
+	// a config file.
+	shardsToListen := cfg.ListenShards
+	if len(shardsToListen) == 0 {
+		for i := ShardIDType(0); i < *listenShards; i++ {
+			shardsToListen = append(shardsToListen, i)
+		}
+	}
+	for _, shardID := range shardsToListen {
+		node.ListenShard(shardID)
 		time.Sleep(time.Millisecond * 30)
 	}
 	node.PublishListeningShards()
@@ -241,13 +294,15 @@ func main() {
 			}(i)
 		}
 	}
+	if cfg.MetricsAddr != "" {
+		go serveMetrics(cfg.MetricsAddr)
+	}
+
 	log.Printf("%v: listening for connections", node.Name())
-	go func() {
-		time.Sleep(time.Second * 1)
-		testClient(rpcAddr)
-	}()
 	// TODO: add "for: n.PublishListeningShards()" back
-	testServer(node, rpcAddr)
+	if err := serveGRPC(node, cfg.RPCAddr); err != nil {
+		log.Fatal(err)
+	}
 
 	// for {
 	// 	log.Println(node.Name())
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"expvar"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+)
+
+// shardDiscoveryLatencyMs exposes each shard's time-to-first-discovered-peer
+// on /debug/vars, keyed by shard ID, for scraping during benchmarks.
+var shardDiscoveryLatencyMs = expvar.NewMap("shard_discovery_latency_ms")
+
+func recordDiscoveryLatency(shardID ShardIDType, d time.Duration) {
+	v := new(expvar.Float)
+	v.Set(float64(d) / float64(time.Millisecond))
+	shardDiscoveryLatencyMs.Set(fmt.Sprintf("%d", shardID), v)
+}
+
+// serveMetrics starts an HTTP server on addr exposing expvar's /debug/vars,
+// which is where shardDiscoveryLatencyMs lives since expvar registers
+// itself against http.DefaultServeMux. It runs until the process exits.
+func serveMetrics(addr string) {
+	log.Printf("metrics listening on %s", addr)
+	if err := http.ListenAndServe(addr, nil); err != nil {
+		log.Printf("metrics: server stopped: %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+// Package netkey reads and writes libp2p private-network keys in the same
+// "/key/swarm/psk/1.0.0/" + "/base16/" + hex text format ipfs-cluster's
+// pnet helper produces, so the same tooling and keys can be reused here.
+package netkey
+
+import (
+	"crypto/rand"
+	"fmt"
+	"os"
+
+	pnet "github.com/libp2p/go-libp2p-pnet"
+)
+
+// Load reads a PSK from path.
+func Load(path string) (pnet.PSK, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("netkey: opening %s: %w", path, err)
+	}
+	defer f.Close()
+
+	psk, err := pnet.DecodeV1PSK(f)
+	if err != nil {
+		return nil, fmt.Errorf("netkey: decoding %s: %w", path, err)
+	}
+	return psk, nil
+}
+
+// WriteNew generates a fresh 32-byte PSK and writes it to path, failing if
+// a file already exists there.
+func WriteNew(path string) error {
+	var key [32]byte
+	if _, err := rand.Read(key[:]); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = fmt.Fprintf(f, "/key/swarm/psk/1.0.0/\n/base16/\n%x\n", key)
+	return err
+}
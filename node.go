@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+
+	crypto "github.com/libp2p/go-libp2p-crypto"
+	peer "github.com/libp2p/go-libp2p-peer"
+	pstore "github.com/libp2p/go-libp2p-peerstore"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	routing "github.com/libp2p/go-libp2p-routing"
+	rhost "github.com/libp2p/go-libp2p/p2p/host/routed"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+// Node wraps a routed libp2p host with the sharding-specific state: which
+// shards we are currently listening on and a human-friendly name used in
+// logs.
+type Node struct {
+	*rhost.RoutedHost
+
+	Routing routing.IpfsRouting
+
+	privKey crypto.PrivKey
+	pubsub  *pubsub.PubSub
+	name    string
+
+	shardsLock sync.Mutex
+	shards     map[ShardIDType]*shardSub
+
+	// shardPeersMu guards shardDiscoveredPeers, which tracks, per shard,
+	// the peers this node connected to via ShardDiscovery.
+	shardPeersMu         sync.Mutex
+	shardDiscoveredPeers map[ShardIDType]map[peer.ID]struct{}
+}
+
+// NewNode wraps host in a Node, using route for peer discovery, ps for
+// shard pubsub, priv to sign outgoing collations, and name to identify this
+// node in logs.
+func NewNode(ctx context.Context, host *rhost.RoutedHost, route routing.IpfsRouting, ps *pubsub.PubSub, priv crypto.PrivKey, name string) *Node {
+	return &Node{
+		RoutedHost: host,
+		Routing:    route,
+		pubsub:     ps,
+		privKey:    priv,
+		name:       name,
+		shards:     make(map[ShardIDType]*shardSub),
+	}
+}
+
+// FindPeer resolves peerID to its known multiaddrs via the DHT.
+func (n *Node) FindPeer(ctx context.Context, peerID peer.ID) (pstore.PeerInfo, error) {
+	return n.Routing.FindPeer(ctx, peerID)
+}
+
+// Name returns the human-friendly identifier this node was created with.
+func (n *Node) Name() string {
+	return n.name
+}
+
+// GetFullAddr returns this node's dialable multiaddr, including its peer ID,
+// suitable for sharing with other nodes as a bootstrap address.
+func (n *Node) GetFullAddr() string {
+	hostAddr, err := ma.NewMultiaddr(fmt.Sprintf("/ipfs/%s", n.ID().Pretty()))
+	if err != nil {
+		log.Fatal(err)
+	}
+	addr := n.Addrs()[0]
+	return addr.Encapsulate(hostAddr).String()
+}
+
+// AddPeer dials and connects to the peer described by the given multiaddr,
+// which must include a `/ipfs/<peerID>` component.
+func (n *Node) AddPeer(targetAddr string) error {
+	ipfsAddr, err := ma.NewMultiaddr(targetAddr)
+	if err != nil {
+		return err
+	}
+	pid, err := ipfsAddr.ValueForProtocol(ma.P_IPFS)
+	if err != nil {
+		return err
+	}
+	peerID, err := peer.IDB58Decode(pid)
+	if err != nil {
+		return err
+	}
+	targetPeerAddr, err := ma.NewMultiaddr(fmt.Sprintf("/ipfs/%s", peer.IDB58Encode(peerID)))
+	if err != nil {
+		return err
+	}
+	targetAddrMa := ipfsAddr.Decapsulate(targetPeerAddr)
+
+	n.Peerstore().AddAddr(peerID, targetAddrMa, pstore.PermanentAddrTTL)
+	pi := pstore.PeerInfo{ID: peerID, Addrs: []ma.Multiaddr{targetAddrMa}}
+	if err := n.Connect(context.Background(), pi); err != nil {
+		return err
+	}
+	log.Printf("%s: connected to %s", n.Name(), peerID.Pretty())
+	return nil
+}
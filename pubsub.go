@@ -0,0 +1,339 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"log"
+	"time"
+
+	gogoproto "github.com/golang/protobuf/proto"
+	crypto "github.com/libp2p/go-libp2p-crypto"
+	host "github.com/libp2p/go-libp2p-host"
+	peer "github.com/libp2p/go-libp2p-peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+
+	pb "github.com/sriharikapu/sharding-p2p-poc/proto"
+)
+
+const (
+	// maxBlobSize caps a collation's blob payload; it matches the ~1MB
+	// filler blob main's -send option publishes.
+	maxBlobSize = 1 << 20 // 1 MiB
+
+	// maxCollationSize caps a single GossipSub message. It must exceed
+	// maxBlobSize by enough headroom for the rest of the marshaled
+	// Collation envelope (proposer_pubkey, blob_hash, signature, shard_id
+	// and period), or the node's own default-sized collations get
+	// rejected by its own validator.
+	maxCollationSize = maxBlobSize + 4096
+
+	manifestTopicName = "/eth2/shard-manifest"
+)
+
+func collationTopicName(shardID ShardIDType) string {
+	return fmt.Sprintf("/eth2/shard/%d/collations", shardID)
+}
+
+// collationTopicScoreParams returns the per-topic score parameters applied
+// to every shard's collation topic: peers are rewarded for delivering
+// first/early copies of a message and penalized for invalid ones, so
+// validateCollation's rejections actually cost a misbehaving peer score.
+func collationTopicScoreParams() *pubsub.TopicScoreParams {
+	return &pubsub.TopicScoreParams{
+		TopicWeight: 1,
+
+		TimeInMeshWeight:  0.01,
+		TimeInMeshQuantum: time.Second,
+		TimeInMeshCap:     10,
+
+		FirstMessageDeliveriesWeight: 1,
+		FirstMessageDeliveriesDecay:  0.5,
+		FirstMessageDeliveriesCap:    10,
+
+		MeshMessageDeliveriesWeight:     -1,
+		MeshMessageDeliveriesDecay:      0.5,
+		MeshMessageDeliveriesCap:        10,
+		MeshMessageDeliveriesThreshold:  5,
+		MeshMessageDeliveriesWindow:     10 * time.Millisecond,
+		MeshMessageDeliveriesActivation: 1 * time.Minute,
+
+		MeshFailurePenaltyWeight: -1,
+		MeshFailurePenaltyDecay:  0.5,
+
+		InvalidMessageDeliveriesWeight: -20,
+		InvalidMessageDeliveriesDecay:  0.3,
+	}
+}
+
+// newPubSub builds a GossipSub router over host with message signing and a
+// peer-scoring setup that rewards first deliveries of valid collations and
+// penalizes malformed or oversized ones. A duplicate of a valid collation
+// carries no score penalty.
+func newPubSub(ctx context.Context, h host.Host) (*pubsub.PubSub, error) {
+	topics := make(map[string]*pubsub.TopicScoreParams, numShards)
+	for shardID := ShardIDType(0); shardID < numShards; shardID++ {
+		topics[collationTopicName(shardID)] = collationTopicScoreParams()
+	}
+
+	scoreParams := &pubsub.PeerScoreParams{
+		Topics:           topics,
+		AppSpecificScore: func(p peer.ID) float64 { return 0 },
+		DecayInterval:    pubsub.DefaultDecayInterval,
+		DecayToZero:      pubsub.DefaultDecayToZero,
+	}
+	scoreThresholds := &pubsub.PeerScoreThresholds{
+		GossipThreshold:   -10,
+		PublishThreshold:  -50,
+		GraylistThreshold: -80,
+	}
+	return pubsub.NewGossipSub(
+		ctx,
+		h,
+		pubsub.WithMessageSigning(true),
+		pubsub.WithMaxMessageSize(maxCollationSize),
+		pubsub.WithPeerScore(scoreParams, scoreThresholds),
+	)
+}
+
+// shardSub tracks a single shard's collation topic/subscription and the
+// channel subscribers read validated collations from.
+type shardSub struct {
+	topic      *pubsub.Topic
+	sub        *pubsub.Subscription
+	collations chan *pb.Collation
+	cancel     context.CancelFunc
+}
+
+// JoinShard joins the GossipSub topic for shardID, registers a validator
+// that checks the blob hash and signature of every collation before it
+// reaches the application, and starts forwarding valid ones to the channel
+// returned by Subscribe.
+func (n *Node) JoinShard(shardID ShardIDType) error {
+	n.shardsLock.Lock()
+	defer n.shardsLock.Unlock()
+	if _, ok := n.shards[shardID]; ok {
+		return nil
+	}
+
+	topicName := collationTopicName(shardID)
+	if err := n.pubsub.RegisterTopicValidator(topicName, n.validateCollation); err != nil {
+		return err
+	}
+
+	topic, err := n.pubsub.Join(topicName)
+	if err != nil {
+		return err
+	}
+	sub, err := topic.Subscribe()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &shardSub{
+		topic:      topic,
+		sub:        sub,
+		collations: make(chan *pb.Collation, 16),
+		cancel:     cancel,
+	}
+	n.shards[shardID] = s
+
+	go s.readLoop(ctx, n.ID())
+	go n.startShardDiscovery(ctx, shardID)
+	return nil
+}
+
+func (s *shardSub) readLoop(ctx context.Context, self peer.ID) {
+	for {
+		msg, err := s.sub.Next(ctx)
+		if err != nil {
+			close(s.collations)
+			return
+		}
+		if msg.ReceivedFrom == self {
+			continue
+		}
+		var c pb.Collation
+		if err := gogoproto.Unmarshal(msg.Data, &c); err != nil {
+			continue
+		}
+		s.collations <- &c
+	}
+}
+
+// validateCollation is a pubsub.Validator that rejects malformed,
+// mis-hashed or unsigned collations before they are delivered to any
+// subscriber, so GossipSub can penalize the misbehaving peer's score.
+func (n *Node) validateCollation(ctx context.Context, from peer.ID, msg *pubsub.Message) bool {
+	if len(msg.Data) > maxCollationSize {
+		return false
+	}
+	var c pb.Collation
+	if err := gogoproto.Unmarshal(msg.Data, &c); err != nil {
+		return false
+	}
+	if len(c.Blob) > maxBlobSize {
+		return false
+	}
+
+	hash := sha256.Sum256(c.Blob)
+	if !bytesEqual(hash[:], c.BlobHash) {
+		return false
+	}
+
+	pubKey, err := crypto.UnmarshalPublicKey(c.ProposerPubkey)
+	if err != nil {
+		return false
+	}
+	ok, err := pubKey.Verify(c.BlobHash, c.Signature)
+	if err != nil || !ok {
+		return false
+	}
+	return true
+}
+
+func bytesEqual(a, b []byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// UnlistenShard leaves the given shard's topic, releasing its
+// subscription, unregistering its validator so the shard can be rejoined
+// later, and closing the channel returned by Subscribe.
+func (n *Node) UnlistenShard(shardID ShardIDType) {
+	n.shardsLock.Lock()
+	defer n.shardsLock.Unlock()
+	s, ok := n.shards[shardID]
+	if !ok {
+		return
+	}
+	s.cancel()
+	s.sub.Cancel()
+	s.topic.Close()
+	n.pubsub.UnregisterTopicValidator(collationTopicName(shardID))
+	delete(n.shards, shardID)
+}
+
+// ListenShard is a convenience wrapper around JoinShard that logs failures
+// instead of returning them, matching how main drives shard subscriptions
+// from a simple loop over -listen-shards.
+func (n *Node) ListenShard(shardID ShardIDType) {
+	if err := n.JoinShard(shardID); err != nil {
+		log.Printf("%s: failed to join shard %d: %v", n.Name(), shardID, err)
+	}
+}
+
+// Subscribe returns the channel of validated collations for shardID,
+// joining the shard first if this node hasn't already.
+func (n *Node) Subscribe(shardID ShardIDType) (<-chan *pb.Collation, error) {
+	n.shardsLock.Lock()
+	s, ok := n.shards[shardID]
+	n.shardsLock.Unlock()
+	if !ok {
+		if err := n.JoinShard(shardID); err != nil {
+			return nil, err
+		}
+		n.shardsLock.Lock()
+		s = n.shards[shardID]
+		n.shardsLock.Unlock()
+	}
+	return s.collations, nil
+}
+
+// ListeningShards returns the shards this node is currently subscribed to.
+func (n *Node) ListeningShards() []ShardIDType {
+	n.shardsLock.Lock()
+	defer n.shardsLock.Unlock()
+	shardIDs := make([]ShardIDType, 0, len(n.shards))
+	for shardID := range n.shards {
+		shardIDs = append(shardIDs, shardID)
+	}
+	return shardIDs
+}
+
+// PublishListeningShards announces the shards this node currently
+// subscribes to on the global manifest topic, so peers can locate shard
+// participants without flooding every shard topic.
+func (n *Node) PublishListeningShards() {
+	manifest := &pb.ShardManifest{
+		PeerId:   n.ID().Pretty(),
+		ShardIds: n.ListeningShards(),
+	}
+	data, err := gogoproto.Marshal(manifest)
+	if err != nil {
+		log.Printf("%s: failed to marshal shard manifest: %v", n.Name(), err)
+		return
+	}
+	topic, err := n.pubsub.Join(manifestTopicName)
+	if err != nil {
+		log.Printf("%s: failed to join shard manifest topic: %v", n.Name(), err)
+		return
+	}
+	if err := topic.Publish(context.Background(), data); err != nil {
+		log.Printf("%s: failed to publish shard manifest: %v", n.Name(), err)
+	}
+}
+
+// Publish signs blob with this node's identity and sends it as a collation
+// for shardID/period, joining the shard topic first if necessary.
+func (n *Node) Publish(shardID ShardIDType, period int64, blob []byte) error {
+	n.shardsLock.Lock()
+	s, ok := n.shards[shardID]
+	n.shardsLock.Unlock()
+	if !ok {
+		if err := n.JoinShard(shardID); err != nil {
+			return err
+		}
+		n.shardsLock.Lock()
+		s = n.shards[shardID]
+		n.shardsLock.Unlock()
+	}
+
+	hash := sha256.Sum256(blob)
+	sig, err := n.privKey.Sign(hash[:])
+	if err != nil {
+		return err
+	}
+	pubKeyBytes, err := crypto.MarshalPublicKey(n.privKey.GetPublic())
+	if err != nil {
+		return err
+	}
+
+	c := &pb.Collation{
+		ShardId:        shardID,
+		Period:         period,
+		ProposerPubkey: pubKeyBytes,
+		BlobHash:       hash[:],
+		Signature:      sig,
+		Blob:           blob,
+	}
+	data, err := gogoproto.Marshal(c)
+	if err != nil {
+		return err
+	}
+	return s.topic.Publish(context.Background(), data)
+}
+
+// SendCollation is the log-and-publish helper main drives from -send; it
+// logs the collation's size and publishes blob as given, logging rather
+// than returning any publish error.
+func (n *Node) SendCollation(shardID ShardIDType, period int64, blob string) {
+	log.Printf(
+		"%s: sending collation shard=%v period=%v size=%v",
+		n.Name(),
+		shardID,
+		period,
+		len(blob),
+	)
+	if err := n.Publish(shardID, period, []byte(blob)); err != nil {
+		log.Printf("%s: failed to publish collation: %v", n.Name(), err)
+	}
+}
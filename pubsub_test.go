@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"testing"
+
+	gogoproto "github.com/golang/protobuf/proto"
+	crypto "github.com/libp2p/go-libp2p-crypto"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+
+	pb "github.com/sriharikapu/sharding-p2p-poc/proto"
+)
+
+// signedCollationMsg builds a pubsub.Message wrapping a Collation signed by
+// a freshly generated key, optionally corrupting the blob after signing so
+// the hash check fails.
+func signedCollationMsg(t *testing.T, blob []byte, corruptAfterSigning bool) *pubsub.Message {
+	t.Helper()
+	priv, pub, err := crypto.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateEd25519Key: %v", err)
+	}
+	pubKeyBytes, err := crypto.MarshalPublicKey(pub)
+	if err != nil {
+		t.Fatalf("MarshalPublicKey: %v", err)
+	}
+
+	hash := sha256.Sum256(blob)
+	sig, err := priv.Sign(hash[:])
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	if corruptAfterSigning {
+		blob = append([]byte(nil), blob...)
+		blob[0] ^= 0xff
+	}
+
+	c := &pb.Collation{
+		ShardId:        0,
+		Period:         0,
+		ProposerPubkey: pubKeyBytes,
+		BlobHash:       hash[:],
+		Signature:      sig,
+		Blob:           blob,
+	}
+	data, err := gogoproto.Marshal(c)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	return &pubsub.Message{Message: &pubsubpb.Message{Data: data}}
+}
+
+func TestValidateCollationAcceptsSignedBlob(t *testing.T) {
+	n := &Node{}
+	msg := signedCollationMsg(t, []byte("a valid collation blob"), false)
+	if !n.validateCollation(context.Background(), "", msg) {
+		t.Fatal("validateCollation rejected a correctly signed collation")
+	}
+}
+
+func TestValidateCollationRejectsTamperedBlob(t *testing.T) {
+	n := &Node{}
+	msg := signedCollationMsg(t, []byte("a valid collation blob"), true)
+	if n.validateCollation(context.Background(), "", msg) {
+		t.Fatal("validateCollation accepted a collation whose blob doesn't match its hash")
+	}
+}
+
+func TestValidateCollationRejectsOversizedBlob(t *testing.T) {
+	n := &Node{}
+	msg := signedCollationMsg(t, make([]byte, maxBlobSize+1), false)
+	if n.validateCollation(context.Background(), "", msg) {
+		t.Fatal("validateCollation accepted a blob larger than maxBlobSize")
+	}
+}
@@ -0,0 +1,94 @@
+package sim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// Server exposes a Network over HTTP so external tooling (not just Go
+// tests) can drive a scenario: create nodes, connect or partition them,
+// and tail the resulting event stream.
+type Server struct {
+	net *Network
+	mux *http.ServeMux
+}
+
+// NewServer wraps net with an HTTP control API.
+func NewServer(net *Network) *Server {
+	s := &Server{net: net, mux: http.NewServeMux()}
+	s.mux.HandleFunc("/nodes", s.handleNodes)
+	s.mux.HandleFunc("/connect", s.handleConnect)
+	s.mux.HandleFunc("/partition", s.handlePartition)
+	s.mux.HandleFunc("/events", s.handleEvents)
+	return s
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) handleNodes(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "POST only", http.StatusMethodNotAllowed)
+		return
+	}
+	var req struct {
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if _, err := s.net.AddNode(context.Background(), req.Name); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (s *Server) handleConnect(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "POST only", http.StatusMethodNotAllowed)
+		return
+	}
+	var req struct {
+		A string `json:"a"`
+		B string `json:"b"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := s.net.Connect(req.A, req.B); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) handlePartition(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "POST only", http.StatusMethodNotAllowed)
+		return
+	}
+	var req struct {
+		GroupA []string `json:"groupA"`
+		GroupB []string `json:"groupB"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := s.net.Partition(req.GroupA, req.GroupB); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.net.Events())
+}
@@ -0,0 +1,139 @@
+package sim
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Event is an entry in a Network's JSON event stream, recording topology
+// and propagation changes so CI can reproduce a run's timeline.
+type Event struct {
+	Type string      `json:"type"`
+	Data interface{} `json:"data,omitempty"`
+}
+
+// Network is a simulated shard-p2p network: a set of named in-process
+// nodes plus whatever topology and scenario code has connected them.
+type Network struct {
+	adapter *InProcAdapter
+
+	mu    sync.Mutex
+	nodes map[string]*Node
+
+	eventsMu sync.Mutex
+	events   []Event
+}
+
+// NewNetwork creates an empty simulated network.
+func NewNetwork() *Network {
+	return &Network{
+		adapter: NewInProcAdapter(),
+		nodes:   make(map[string]*Node),
+	}
+}
+
+func (net *Network) emit(eventType string, data interface{}) {
+	net.eventsMu.Lock()
+	defer net.eventsMu.Unlock()
+	net.events = append(net.events, Event{Type: eventType, Data: data})
+}
+
+// Events returns every event recorded so far, in order.
+func (net *Network) Events() []Event {
+	net.eventsMu.Lock()
+	defer net.eventsMu.Unlock()
+	out := make([]Event, len(net.events))
+	copy(out, net.events)
+	return out
+}
+
+// AddNode creates a new node called name with no connections yet.
+func (net *Network) AddNode(ctx context.Context, name string) (*Node, error) {
+	net.mu.Lock()
+	defer net.mu.Unlock()
+	if _, ok := net.nodes[name]; ok {
+		return nil, fmt.Errorf("sim: node %q already exists", name)
+	}
+
+	h, err := net.adapter.NewHost()
+	if err != nil {
+		return nil, err
+	}
+	node, err := newNode(ctx, name, h)
+	if err != nil {
+		return nil, err
+	}
+	net.nodes[name] = node
+	net.emit("node_added", name)
+	return node, nil
+}
+
+// Node returns the node called name, or nil if it doesn't exist.
+func (net *Network) Node(name string) *Node {
+	net.mu.Lock()
+	defer net.mu.Unlock()
+	return net.nodes[name]
+}
+
+// Nodes returns every node in the network.
+func (net *Network) Nodes() []*Node {
+	net.mu.Lock()
+	defer net.mu.Unlock()
+	out := make([]*Node, 0, len(net.nodes))
+	for _, n := range net.nodes {
+		out = append(out, n)
+	}
+	return out
+}
+
+// Connect links and connects the named nodes.
+func (net *Network) Connect(a, b string) error {
+	na, nb := net.Node(a), net.Node(b)
+	if na == nil || nb == nil {
+		return fmt.Errorf("sim: connect: unknown node in (%q, %q)", a, b)
+	}
+	if err := net.adapter.ConnectPeers(na.Host, nb.Host); err != nil {
+		return err
+	}
+	net.emit("connected", [2]string{a, b})
+	return nil
+}
+
+// Partition disconnects every node in groupA from every node in groupB,
+// simulating a network split while leaving each group internally
+// connected.
+func (net *Network) Partition(groupA, groupB []string) error {
+	for _, a := range groupA {
+		na := net.Node(a)
+		if na == nil {
+			return fmt.Errorf("sim: partition: unknown node %q", a)
+		}
+		for _, b := range groupB {
+			nb := net.Node(b)
+			if nb == nil {
+				return fmt.Errorf("sim: partition: unknown node %q", b)
+			}
+			if err := net.adapter.DisconnectPeers(na.Host, nb.Host); err != nil {
+				return err
+			}
+		}
+	}
+	net.emit("partitioned", map[string][]string{"groupA": groupA, "groupB": groupB})
+	return nil
+}
+
+// Heal connects every node in groupA to every node in groupB. After a
+// Partition this restores the cut connections, and also connects any pair
+// that was not connected before the Partition.
+func (net *Network) Heal(groupA, groupB []string) error {
+	for _, a := range groupA {
+		for _, b := range groupB {
+			if err := net.Connect(a, b); err != nil {
+				return err
+			}
+		}
+	}
+	net.emit("healed", map[string][]string{"groupA": groupA, "groupB": groupB})
+	return nil
+}
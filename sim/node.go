@@ -0,0 +1,179 @@
+package sim
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+
+	gogoproto "github.com/golang/protobuf/proto"
+	crypto "github.com/libp2p/go-libp2p-crypto"
+	host "github.com/libp2p/go-libp2p-host"
+	peer "github.com/libp2p/go-libp2p-peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+
+	pb "github.com/sriharikapu/sharding-p2p-poc/proto"
+)
+
+func collationTopicName(shardID int64) string {
+	return fmt.Sprintf("/eth2/shard/%d/collations", shardID)
+}
+
+// Node is a simulated shard-p2p participant: a libp2p host plus the subset
+// of the real Node's collation pubsub behavior scenarios need. It is
+// intentionally self-contained rather than reusing the production Node
+// (package main can't be imported), but signs collations and checks their
+// blob hash and signature the same way pubsub.go does, so a scenario run
+// here exercises the same wire protocol. Unlike the real node, it applies
+// no blob or message size caps of its own.
+type Node struct {
+	Name string
+	Host host.Host
+
+	privKey crypto.PrivKey
+	pubsub  *pubsub.PubSub
+
+	mu     sync.Mutex
+	shards map[int64]*pubsub.Topic
+	subs   map[int64]*pubsub.Subscription
+}
+
+// newNode wraps h as a simulation Node called name, signing outgoing
+// collations with h's own libp2p identity key.
+func newNode(ctx context.Context, name string, h host.Host) (*Node, error) {
+	privKey := h.Peerstore().PrivKey(h.ID())
+	if privKey == nil {
+		// Mocknet hosts always register their identity key, but fall back
+		// to a freshly generated one rather than signing with nil.
+		var err error
+		privKey, _, err = crypto.GenerateEd25519Key(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+	}
+	ps, err := pubsub.NewGossipSub(ctx, h)
+	if err != nil {
+		return nil, err
+	}
+	return &Node{
+		Name:    name,
+		Host:    h,
+		privKey: privKey,
+		pubsub:  ps,
+		shards:  make(map[int64]*pubsub.Topic),
+		subs:    make(map[int64]*pubsub.Subscription),
+	}, nil
+}
+
+// validateCollation mirrors pubsub.go's validateCollation: it rejects any
+// collation whose blob doesn't hash to blob_hash or whose signature doesn't
+// verify against proposer_pubkey.
+func validateCollation(ctx context.Context, from peer.ID, msg *pubsub.Message) bool {
+	var c pb.Collation
+	if err := gogoproto.Unmarshal(msg.Data, &c); err != nil {
+		return false
+	}
+
+	hash := sha256.Sum256(c.Blob)
+	if len(hash) != len(c.BlobHash) {
+		return false
+	}
+	for i := range hash {
+		if hash[i] != c.BlobHash[i] {
+			return false
+		}
+	}
+
+	pubKey, err := crypto.UnmarshalPublicKey(c.ProposerPubkey)
+	if err != nil {
+		return false
+	}
+	ok, err := pubKey.Verify(c.BlobHash, c.Signature)
+	if err != nil || !ok {
+		return false
+	}
+	return true
+}
+
+// Join subscribes to shardID's collation topic, registering the same
+// hash/signature validator pubsub.go's JoinShard does, and returns the
+// topic handle so scenarios can Publish on it directly.
+func (n *Node) Join(shardID int64) (*pubsub.Topic, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if topic, ok := n.shards[shardID]; ok {
+		return topic, nil
+	}
+
+	topicName := collationTopicName(shardID)
+	if err := n.pubsub.RegisterTopicValidator(topicName, validateCollation); err != nil {
+		return nil, err
+	}
+
+	topic, err := n.pubsub.Join(topicName)
+	if err != nil {
+		return nil, err
+	}
+	sub, err := topic.Subscribe()
+	if err != nil {
+		return nil, err
+	}
+	n.shards[shardID] = topic
+	n.subs[shardID] = sub
+	return topic, nil
+}
+
+// Publish signs blob with this node's identity and sends it as a collation
+// for shardID/period, the same way pubsub.go's Publish does.
+func (n *Node) Publish(ctx context.Context, shardID, period int64, blob []byte) error {
+	topic, err := n.Join(shardID)
+	if err != nil {
+		return err
+	}
+
+	hash := sha256.Sum256(blob)
+	sig, err := n.privKey.Sign(hash[:])
+	if err != nil {
+		return err
+	}
+	pubKeyBytes, err := crypto.MarshalPublicKey(n.privKey.GetPublic())
+	if err != nil {
+		return err
+	}
+
+	c := &pb.Collation{
+		ShardId:        shardID,
+		Period:         period,
+		ProposerPubkey: pubKeyBytes,
+		BlobHash:       hash[:],
+		Signature:      sig,
+		Blob:           blob,
+	}
+	data, err := gogoproto.Marshal(c)
+	if err != nil {
+		return err
+	}
+	return topic.Publish(ctx, data)
+}
+
+// Next blocks until shardID's next validated collation arrives, or ctx is
+// done.
+func (n *Node) Next(ctx context.Context, shardID int64) (*pb.Collation, error) {
+	if _, err := n.Join(shardID); err != nil {
+		return nil, err
+	}
+	n.mu.Lock()
+	sub := n.subs[shardID]
+	n.mu.Unlock()
+
+	msg, err := sub.Next(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var c pb.Collation
+	if err := gogoproto.Unmarshal(msg.Data, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
@@ -0,0 +1,105 @@
+package sim
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// BroadcastResult reports how long each receiving node took to see a
+// collation published by BroadcastCollation.
+type BroadcastResult struct {
+	Proposer  string
+	Latencies map[string]time.Duration
+}
+
+// BroadcastCollation joins every other node in net to shardID, publishes a
+// collation for shardID from proposer and waits (up to timeout) for each of
+// those nodes to receive it, recording per-node propagation latency.
+func BroadcastCollation(ctx context.Context, net *Network, shardID int64, proposer string, blob []byte, timeout time.Duration) (*BroadcastResult, error) {
+	proposerNode := net.Node(proposer)
+	if proposerNode == nil {
+		return nil, fmt.Errorf("sim: broadcast: unknown proposer %q", proposer)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type recv struct {
+		name string
+		d    time.Duration
+		err  error
+	}
+	recvCh := make(chan recv, len(net.Nodes()))
+
+	start := time.Now()
+	waiting := 0
+	for _, n := range net.Nodes() {
+		if n.Name == proposer {
+			continue
+		}
+		waiting++
+		go func(n *Node) {
+			_, err := n.Next(ctx, shardID)
+			recvCh <- recv{name: n.Name, d: time.Since(start), err: err}
+		}(n)
+	}
+
+	// Give subscriptions a moment to register with the pubsub mesh before
+	// publishing.
+	time.Sleep(50 * time.Millisecond)
+	if err := proposerNode.Publish(ctx, shardID, 0, blob); err != nil {
+		return nil, err
+	}
+	net.emit("collation_published", map[string]interface{}{"shard_id": shardID, "proposer": proposer})
+
+	result := &BroadcastResult{Proposer: proposer, Latencies: make(map[string]time.Duration)}
+	for i := 0; i < waiting; i++ {
+		r := <-recvCh
+		if r.err != nil {
+			continue
+		}
+		result.Latencies[r.name] = r.d
+	}
+	net.emit("collation_propagated", result)
+	return result, nil
+}
+
+// Churn repeatedly disconnects and reconnects a random node from the
+// network every interval, for the given duration, to exercise GossipSub's
+// mesh repair under membership changes.
+func Churn(ctx context.Context, net *Network, names []string, interval, duration time.Duration, rng *rand.Rand) error {
+	deadline := time.Now().Add(duration)
+	for time.Now().Before(deadline) {
+		victim := names[rng.Intn(len(names))]
+		others := make([]string, 0, len(names)-1)
+		for _, n := range names {
+			if n != victim {
+				others = append(others, n)
+			}
+		}
+		if err := net.Partition([]string{victim}, others); err != nil {
+			return err
+		}
+		net.emit("churn_disconnect", victim)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+
+		if err := net.Heal([]string{victim}, others); err != nil {
+			return err
+		}
+		net.emit("churn_reconnect", victim)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package sim
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// newTestNetwork builds a Network of n nodes named "node0".."node(n-1)",
+// connected with topology, ready for a scenario to run against.
+func newTestNetwork(t *testing.T, n int, topology func(*Network, []string) error) (*Network, []string) {
+	t.Helper()
+	net := NewNetwork()
+	names := make([]string, n)
+	for i := range names {
+		names[i] = fmt.Sprintf("node%d", i)
+		if _, err := net.AddNode(context.Background(), names[i]); err != nil {
+			t.Fatalf("AddNode(%s): %v", names[i], err)
+		}
+	}
+	if err := topology(net, names); err != nil {
+		t.Fatalf("topology: %v", err)
+	}
+	return net, names
+}
+
+func TestBroadcastCollationRing(t *testing.T) {
+	net, names := newTestNetwork(t, 4, Ring)
+
+	blob := []byte("hello shard 0")
+	result, err := BroadcastCollation(context.Background(), net, 0, names[0], blob, 5*time.Second)
+	if err != nil {
+		t.Fatalf("BroadcastCollation: %v", err)
+	}
+
+	for _, name := range names[1:] {
+		if _, ok := result.Latencies[name]; !ok {
+			t.Errorf("node %s never received the collation", name)
+		}
+	}
+}
+
+func TestBroadcastCollationMesh(t *testing.T) {
+	net, names := newTestNetwork(t, 5, Mesh)
+
+	blob := []byte("hello shard 1")
+	result, err := BroadcastCollation(context.Background(), net, 1, names[0], blob, 5*time.Second)
+	if err != nil {
+		t.Fatalf("BroadcastCollation: %v", err)
+	}
+
+	for _, name := range names[1:] {
+		if _, ok := result.Latencies[name]; !ok {
+			t.Errorf("node %s never received the collation", name)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package sim
+
+import "math/rand"
+
+// Ring connects each node in names to the next, wrapping the last back to
+// the first.
+func Ring(net *Network, names []string) error {
+	for i := range names {
+		if err := net.Connect(names[i], names[(i+1)%len(names)]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Star connects center to every node in leaves.
+func Star(net *Network, center string, leaves []string) error {
+	for _, leaf := range leaves {
+		if err := net.Connect(center, leaf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RandomKRegular connects each node in names to k distinct others chosen
+// deterministically from rng, approximating a random k-regular graph.
+// Callers that need reproducible runs should pass a rand.Rand seeded with
+// a fixed value.
+func RandomKRegular(net *Network, names []string, k int, rng *rand.Rand) error {
+	if k >= len(names) {
+		return Mesh(net, names)
+	}
+
+	connected := make(map[[2]string]bool)
+	connect := func(a, b string) error {
+		key := [2]string{a, b}
+		if a > b {
+			key = [2]string{b, a}
+		}
+		if connected[key] {
+			return nil
+		}
+		connected[key] = true
+		return net.Connect(a, b)
+	}
+
+	for _, name := range names {
+		peers := rng.Perm(len(names))
+		linked := 0
+		for _, idx := range peers {
+			if linked >= k {
+				break
+			}
+			other := names[idx]
+			if other == name {
+				continue
+			}
+			if err := connect(name, other); err != nil {
+				return err
+			}
+			linked++
+		}
+	}
+	return nil
+}
+
+// Mesh connects every node in names to every other node.
+func Mesh(net *Network, names []string) error {
+	for i := range names {
+		for j := i + 1; j < len(names); j++ {
+			if err := net.Connect(names[i], names[j]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	pstore "github.com/libp2p/go-libp2p-peerstore"
+	host "github.com/libp2p/go-libp2p-host"
+)
+
+// bootstrapConnect dials each of the given peers concurrently, logging but
+// not failing on individual connection errors since the DHT will continue
+// trying to find peers on its own.
+func bootstrapConnect(ctx context.Context, h host.Host, peers []pstore.PeerInfo) {
+	if len(peers) == 0 {
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, p := range peers {
+		wg.Add(1)
+		go func(p pstore.PeerInfo) {
+			defer wg.Done()
+			h.Peerstore().AddAddrs(p.ID, p.Addrs, pstore.PermanentAddrTTL)
+			if err := h.Connect(ctx, p); err != nil {
+				log.Printf("bootstrapConnect: failed to connect to %s: %v", p.ID, err)
+				return
+			}
+			log.Printf("bootstrapConnect: connected to %s", p.ID)
+		}(p)
+	}
+	wg.Wait()
+}